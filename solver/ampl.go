@@ -0,0 +1,78 @@
+// Package solver drives the AMPL models in AMPL/ from Go: it writes .dat
+// files, shells out to the ampl binary against the matching .run file, and
+// scrapes back the printf/display lines the .run files emit.
+package solver
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// RunAMPL invokes `ampl runFile` and returns its stdout and stderr,
+// combined in one string. It fails loudly
+// rather than guessing at a partial result if ampl exits non-zero.
+func RunAMPL(runFile string) (string, error) {
+	cmd := exec.Command("ampl", runFile)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("ampl %s: %w\n%s", runFile, err, out.String())
+	}
+	return out.String(), nil
+}
+
+// WriteDat writes name/value pairs as an AMPL .dat `param ... :=` block,
+// one param statement per entry, in the order given.
+func WriteDat(path string, params []DatParam) error {
+	var b strings.Builder
+	for _, p := range params {
+		b.WriteString(p.render())
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// DatParam renders a single scalar or indexed param statement for a .dat file.
+type DatParam struct {
+	Name    string
+	Scalar  *float64           // set for a plain "param name := v;"
+	Indexed map[string]float64 // set for "param name := k1 v1 k2 v2 ...;"
+}
+
+func (p DatParam) render() string {
+	if p.Scalar != nil {
+		return fmt.Sprintf("param %s := %s;\n", p.Name, strconv.FormatFloat(*p.Scalar, 'g', -1, 64))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "param %s :=\n", p.Name)
+	for k, v := range p.Indexed {
+		fmt.Fprintf(&b, "\t%s %s\n", k, strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	b.WriteString(";\n")
+	return b.String()
+}
+
+// ScrapeInt pulls the integer following prefix on its own line out of an
+// ampl .run file's stdout, e.g. ScrapeInt(out, "alive bases armed ").
+func ScrapeInt(out, prefix string) (int, error) {
+	for _, line := range strings.Split(out, "\n") {
+		if rest, ok := strings.CutPrefix(line, prefix); ok {
+			return strconv.Atoi(strings.TrimSpace(rest))
+		}
+	}
+	return 0, fmt.Errorf("prefix %q not found in ampl output", prefix)
+}
+
+// ScrapeStatus pulls the solve_result word reported by `printf "status %s\n"`.
+func ScrapeStatus(out string) (string, error) {
+	for _, line := range strings.Split(out, "\n") {
+		if rest, ok := strings.CutPrefix(line, "status "); ok {
+			return strings.TrimSpace(rest), nil
+		}
+	}
+	return "", fmt.Errorf("status line not found in ampl output")
+}
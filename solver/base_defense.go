@@ -0,0 +1,38 @@
+package solver
+
+import "path/filepath"
+
+// BaseDefenseInstance is the plain input to base_defense.mod: a graph plus
+// which nodes are critical points.
+type BaseDefenseInstance struct {
+	V               []string
+	E               []Edge
+	IsCriticalPoint map[string]bool
+	DatDir          string
+}
+
+// SolveBaseDefense writes base_defense.dat and runs base_defense.run,
+// returning the minimum number of armed bases.
+func SolveBaseDefense(inst BaseDefenseInstance) (int, error) {
+	datPath := filepath.Join(inst.DatDir, "base_defense.dat")
+	if err := writeBaseDefenseDat(datPath, inst); err != nil {
+		return 0, err
+	}
+
+	out, err := RunAMPL(filepath.Join(inst.DatDir, "base_defense.run"))
+	if err != nil {
+		return 0, err
+	}
+	return ScrapeInt(out, "armed bases ")
+}
+
+func writeBaseDefenseDat(path string, inst BaseDefenseInstance) error {
+	isCritical := map[string]float64{}
+	for _, v := range inst.V {
+		isCritical[v] = boolFloat(inst.IsCriticalPoint[v])
+	}
+	if err := WriteDat(path, []DatParam{{Name: "is_critical_point", Indexed: isCritical}}); err != nil {
+		return err
+	}
+	return appendSetAndEdges(path, inst.V, inst.E)
+}
@@ -0,0 +1,196 @@
+package solver
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DADInstance is the tri-level defender-attacker-defender input: the graph
+// from base_defense.mod plus the hardening and attack parameters added in
+// base_defense_dad.mod.
+type DADInstance struct {
+	V               []string
+	IsCriticalPoint map[string]bool
+	Neighbors       map[string][]string
+	HardenBudget    float64
+	HardenCost      map[string]float64
+	AttackBudget    int
+	DatDir          string // where *.dat files are (over)written, e.g. "AMPL"
+}
+
+// AttackScenario is one destroy-set evaluated against the inner covering
+// problem, paired with the arming cost it forced.
+type AttackScenario struct {
+	Destroyed []string
+	InnerCost int
+}
+
+// DADResult is the outcome of SolveDAD: the hardening plan, the worst
+// attack found against it, and the inner arming cost that attack forces
+// (WorstCase.InnerCost). The arming itself (which bases to_arm selects) is
+// not returned.
+type DADResult struct {
+	Hardened   []string
+	WorstCase  AttackScenario
+	Iterations int
+}
+
+// SolveDAD runs the Benders-style decomposition described in
+// base_defense_dad_master.mod / base_defense_dad.mod: solve the master for a
+// hardening plan, find the attacker's best response to it, solve the inner
+// covering problem on the survivors, and feed the realized cost back to the
+// master as an optimality cut. Stops once the master's eta matches the
+// worst-case cost actually realized, or after maxIter safety rounds.
+func SolveDAD(inst DADInstance, maxIter int) (DADResult, error) {
+	var cuts []cutRow
+	bigM := float64(len(inst.V)) + 1
+
+	var hardened []string
+	var worst AttackScenario
+
+	for iter := 0; iter < maxIter; iter++ {
+		masterDat := filepath.Join(inst.DatDir, "base_defense_dad_master.dat")
+		if err := writeMasterDat(masterDat, inst, cuts, bigM); err != nil {
+			return DADResult{}, err
+		}
+		out, err := RunAMPL(filepath.Join(inst.DatDir, "base_defense_dad_master.run"))
+		if err != nil {
+			return DADResult{}, err
+		}
+		eta, err := ScrapeInt(out, "eta ")
+		if err != nil {
+			return DADResult{}, err
+		}
+		hardened = scrapeHardened(out, inst.V)
+
+		attack := bestAttack(inst, hardened)
+		innerCost, err := solveInner(inst, hardened, attack)
+		if err != nil {
+			return DADResult{}, err
+		}
+
+		if innerCost <= eta {
+			return DADResult{Hardened: hardened, WorstCase: AttackScenario{Destroyed: attack, InnerCost: innerCost}, Iterations: iter + 1}, nil
+		}
+
+		worst = AttackScenario{Destroyed: attack, InnerCost: innerCost}
+		cuts = append(cuts, cutRow{attack: attack, value: innerCost})
+	}
+
+	return DADResult{Hardened: hardened, WorstCase: worst, Iterations: maxIter}, fmt.Errorf("dad: no convergence after %d iterations", maxIter)
+}
+
+type cutRow struct {
+	attack []string
+	value  int
+}
+
+// bestAttack enumerates destroy-sets of size up to AttackBudget among the
+// non-hardened bases and returns the one forcing the highest inner arming
+// cost. The search space is the combinatorial attacker subproblem; for
+// instances too large to enumerate, replace this with its own MILP as
+// base_defense_dad_master.mod's optimality cuts already anticipate.
+func bestAttack(inst DADInstance, hardened []string) []string {
+	isHardened := toSet(hardened)
+	var live []string
+	for _, v := range inst.V {
+		if !isHardened[v] {
+			live = append(live, v)
+		}
+	}
+
+	var best []string
+	bestCost := -1
+	for _, combo := range subsetsUpTo(live, inst.AttackBudget) {
+		cost, err := solveInner(inst, hardened, combo)
+		if err != nil {
+			continue
+		}
+		if cost > bestCost {
+			bestCost, best = cost, combo
+		}
+	}
+	return best
+}
+
+func solveInner(inst DADInstance, hardened, destroyed []string) (int, error) {
+	isHardened := toSet(hardened)
+	isDestroyed := toSet(destroyed)
+
+	alive := map[string]float64{}
+	for _, v := range inst.V {
+		if isDestroyed[v] && !isHardened[v] {
+			alive[v] = 0
+		} else {
+			alive[v] = 1
+		}
+	}
+
+	datPath := filepath.Join(inst.DatDir, "base_defense_dad.dat")
+	if err := writeInnerDat(datPath, inst, hardened, alive); err != nil {
+		return 0, err
+	}
+	out, err := RunAMPL(filepath.Join(inst.DatDir, "base_defense_dad.run"))
+	if err != nil {
+		return 0, err
+	}
+	return ScrapeInt(out, "alive bases armed ")
+}
+
+func writeInnerDat(path string, inst DADInstance, hardened []string, alive map[string]float64) error {
+	isHardened := toSet(hardened)
+	hardenVals := map[string]float64{}
+	isCritical := map[string]float64{}
+	for _, v := range inst.V {
+		hardenVals[v] = boolFloat(isHardened[v])
+		isCritical[v] = boolFloat(inst.IsCriticalPoint[v])
+	}
+	budget := inst.HardenBudget
+	if err := WriteDat(path, []DatParam{
+		{Name: "alive", Indexed: alive},
+		{Name: "harden_fixed", Indexed: hardenVals},
+		{Name: "harden_cost", Indexed: inst.HardenCost},
+		{Name: "harden_budget", Scalar: &budget},
+		{Name: "is_critical_point", Indexed: isCritical},
+	}); err != nil {
+		return err
+	}
+	return appendSetAndEdges(path, inst.V, edgesFromNeighbors(inst.Neighbors))
+}
+
+func writeMasterDat(path string, inst DADInstance, cuts []cutRow, bigM float64) error {
+	budget := inst.HardenBudget
+	var b []byte
+	b = append(b, []byte(fmt.Sprintf("set V := %s;\n", joinIdents(inst.V)))...)
+	b = append(b, []byte(fmt.Sprintf("param harden_budget := %v;\n", budget))...)
+	b = append(b, []byte(fmt.Sprintf("param big_m := %v;\n", bigM))...)
+	b = append(b, []byte(renderIndexed("harden_cost", inst.HardenCost))...)
+
+	b = append(b, []byte(fmt.Sprintf("set CUTS := %s;\n", joinRange(len(cuts))))...)
+	b = append(b, []byte("param cut_value :=\n")...)
+	for i, c := range cuts {
+		b = append(b, []byte(fmt.Sprintf("\t%d %d\n", i+1, c.value))...)
+	}
+	b = append(b, []byte(";\n")...)
+
+	// cut_attack is a CUTS x V table; AMPL's tabular form needs the column
+	// labels on a "param cut_attack: v1 v2 ... :=" header row.
+	b = append(b, []byte(fmt.Sprintf("param cut_attack: %s :=\n", strings.Join(inst.V, " ")))...)
+	for i, c := range cuts {
+		attacked := toSet(c.attack)
+		b = append(b, []byte(fmt.Sprintf("%d", i+1))...)
+		for _, v := range inst.V {
+			if attacked[v] {
+				b = append(b, []byte(" 1")...)
+			} else {
+				b = append(b, []byte(" 0")...)
+			}
+		}
+		b = append(b, '\n')
+	}
+	b = append(b, []byte(";\n")...)
+
+	return os.WriteFile(path, b, 0o644)
+}
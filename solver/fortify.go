@@ -0,0 +1,151 @@
+package solver
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// FortifyInstance is the multi-round input for base_defense_fortify.mod:
+// the graph, the horizon, the per-round fortification budget, and the
+// threat's starting set.
+type FortifyInstance struct {
+	V                   []string
+	E                   []Edge
+	IsCriticalPoint     map[string]bool
+	Horizon             int
+	KPerRound           float64
+	ResistanceThreshold float64
+	InitiallyThreatened map[string]bool
+	DatDir              string
+}
+
+// FortifyResult is the outcome of a solve: the two objective values. The
+// chosen resistance schedule is not parsed back from the ampl output.
+type FortifyResult struct {
+	BasesLost  int
+	TotalSpend int
+}
+
+// SolveFortify writes base_defense_fortify.dat and runs
+// base_defense_fortify.run.
+func SolveFortify(inst FortifyInstance) (FortifyResult, error) {
+	datPath := filepath.Join(inst.DatDir, "base_defense_fortify.dat")
+	if err := writeFortifyDat(datPath, inst); err != nil {
+		return FortifyResult{}, err
+	}
+
+	out, err := RunAMPL(filepath.Join(inst.DatDir, "base_defense_fortify.run"))
+	if err != nil {
+		return FortifyResult{}, err
+	}
+
+	basesLost, err := ScrapeInt(out, "bases lost ")
+	if err != nil {
+		return FortifyResult{}, err
+	}
+	totalSpend, err := ScrapeInt(out, "total spend ")
+	if err != nil {
+		return FortifyResult{}, err
+	}
+	return FortifyResult{BasesLost: basesLost, TotalSpend: totalSpend}, nil
+}
+
+func writeFortifyDat(path string, inst FortifyInstance) error {
+	horizon := float64(inst.Horizon)
+	kPerRound := inst.KPerRound
+	threshold := inst.ResistanceThreshold
+
+	isCritical := map[string]float64{}
+	threatened := map[string]float64{}
+	for _, v := range inst.V {
+		isCritical[v] = boolFloat(inst.IsCriticalPoint[v])
+		threatened[v] = boolFloat(inst.InitiallyThreatened[v])
+	}
+
+	params := []DatParam{
+		{Name: "horizon", Scalar: &horizon},
+		{Name: "k_per_round", Scalar: &kPerRound},
+		{Name: "resistance_threshold", Scalar: &threshold},
+		{Name: "is_critical_point", Indexed: isCritical},
+		{Name: "initially_threatened", Indexed: threatened},
+	}
+	if err := WriteDat(path, params); err != nil {
+		return err
+	}
+	return appendSetAndEdges(path, inst.V, inst.E)
+}
+
+func boolFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// appendSetAndEdges appends the "set V" and "set E" statements that
+// DatParam doesn't model, since WriteDat only renders param blocks.
+func appendSetAndEdges(path string, v []string, e []Edge) error {
+	content := fmt.Sprintf("set V := %s;\nset E := %s;\n", joinIdents(v), joinEdgeTuples(e))
+	return appendFile(path, content)
+}
+
+func joinEdgeTuples(e []Edge) string {
+	tuples := make([]string, len(e))
+	for i, edge := range e {
+		tuples[i] = fmt.Sprintf("(%s,%s)", edge[0], edge[1])
+	}
+	out := ""
+	for i, t := range tuples {
+		if i > 0 {
+			out += " "
+		}
+		out += t
+	}
+	return out
+}
+
+// regressionFortifyInstances are small grid instances for which the minimal
+// k_per_round needed to lose zero critical bases is known by construction:
+// a single critical base at the grid's center, threat seeded at the
+// corners, resistance_threshold 1, so one unit of resistance at the center
+// per round suffices once the threat is adjacent.
+var regressionFortifyInstances = []struct {
+	Name              string
+	Rows, Cols        int
+	ExpectedKPerRound float64
+}{
+	{Name: "grid_3x3_corner_threat", Rows: 3, Cols: 3, ExpectedKPerRound: 1},
+	{Name: "grid_5x5_corner_threat", Rows: 5, Cols: 5, ExpectedKPerRound: 1},
+}
+
+// buildFortifyRegressionInstance turns one of regressionFortifyInstances
+// into a solvable FortifyInstance: the grid's center cell is the sole
+// critical point, the four corners are initially threatened, and the
+// horizon is generous enough for the threat to reach the center along any
+// shortest grid path.
+func buildFortifyRegressionInstance(rows, cols int, kPerRound float64, datDir string) FortifyInstance {
+	v, e := GridGraph(rows, cols)
+	center := fmt.Sprintf("%d_%d", rows/2, cols/2)
+	corners := []string{
+		fmt.Sprintf("%d_%d", 0, 0),
+		fmt.Sprintf("%d_%d", 0, cols-1),
+		fmt.Sprintf("%d_%d", rows-1, 0),
+		fmt.Sprintf("%d_%d", rows-1, cols-1),
+	}
+
+	initiallyThreatened := map[string]bool{}
+	for _, c := range corners {
+		initiallyThreatened[c] = true
+	}
+
+	return FortifyInstance{
+		V:                   v,
+		E:                   e,
+		IsCriticalPoint:     map[string]bool{center: true},
+		Horizon:             rows + cols, // >= the longest corner-to-center grid distance
+		KPerRound:           kPerRound,
+		ResistanceThreshold: 1,
+		InitiallyThreatened: initiallyThreatened,
+		DatDir:              datDir,
+	}
+}
@@ -0,0 +1,30 @@
+package solver
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// TestFortifyRegressionInstances checks regressionFortifyInstances: with
+// each entry's ExpectedKPerRound, the center base should never be lost,
+// since one unit of resistance placed there in round 1 meets
+// resistance_threshold before the threat can arrive from any corner.
+func TestFortifyRegressionInstances(t *testing.T) {
+	if _, err := exec.LookPath("ampl"); err != nil {
+		t.Skip("ampl not found on PATH, skipping solver regression suite")
+	}
+
+	for _, tc := range regressionFortifyInstances {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			inst := buildFortifyRegressionInstance(tc.Rows, tc.Cols, tc.ExpectedKPerRound, "../AMPL")
+			got, err := SolveFortify(inst)
+			if err != nil {
+				t.Fatalf("SolveFortify(%s): %v", tc.Name, err)
+			}
+			if got.BasesLost != 0 {
+				t.Errorf("%s: k_per_round=%g lost %d bases, want 0", tc.Name, tc.ExpectedKPerRound, got.BasesLost)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package solver
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// GameInstance is the simultaneous attacker/defender game input shared by
+// base_defense_game.mod: the graph from base_defense.mod plus each side's
+// budget.
+type GameInstance struct {
+	V               []string
+	IsCriticalPoint map[string]bool
+	Neighbors       map[string][]string
+	AttackBudget    int
+	DefenseBudget   int
+	DatDir          string
+}
+
+// GameResult is the outcome of PlayGame: either a pure Nash equilibrium
+// found by best response, or a mixed-strategy equilibrium over the
+// strategy pool discovered once the iteration cycled.
+type GameResult struct {
+	Converged       bool
+	AttackStrategy  []string           // pure strategy, set when Converged
+	DefenseStrategy []string           // pure strategy, set when Converged
+	AttackMixed     map[string]float64 // strategy key -> probability, set otherwise
+	DefenseMixed    map[string]float64
+	Value           float64
+	Iterations      int
+}
+
+// neutralizedCount is the shared payoff: the number of critical bases that
+// are attacked and left undefended under a given pair of pure strategies.
+// Used only to score the already-discovered strategy pool when building
+// the Nash fallback's payoff matrix; each player's actual best response is
+// computed by solving base_defense_game.mod (see attackerBestResponse /
+// defenderBestResponse below).
+func neutralizedCount(inst GameInstance, attacked, armed map[string]bool) int {
+	n := 0
+	for _, v := range inst.V {
+		if !inst.IsCriticalPoint[v] || !attacked[v] {
+			continue
+		}
+		covered := armed[v]
+		if !covered {
+			for _, nb := range inst.Neighbors[v] {
+				if armed[nb] {
+					covered = true
+					break
+				}
+			}
+		}
+		if !covered {
+			n++
+		}
+	}
+	return n
+}
+
+// attackerBestResponse solves base_defense_game.mod with solving_player=1
+// and to_arm pinned to armed, returning the attacker's best response.
+func attackerBestResponse(inst GameInstance, armed map[string]bool) (map[string]bool, error) {
+	out, err := runGameRound(inst, 1, map[string]bool{}, armed)
+	if err != nil {
+		return nil, err
+	}
+	return scrapeDisplayBlock(out, "attack", inst.V), nil
+}
+
+// defenderBestResponse solves base_defense_game.mod with solving_player=2
+// and attack pinned to attacked, returning the defender's best response.
+func defenderBestResponse(inst GameInstance, attacked map[string]bool) (map[string]bool, error) {
+	out, err := runGameRound(inst, 2, attacked, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return scrapeDisplayBlock(out, "to_arm", inst.V), nil
+}
+
+func runGameRound(inst GameInstance, solvingPlayer int, attackFixed, toArmFixed map[string]bool) (string, error) {
+	datPath := filepath.Join(inst.DatDir, "base_defense_game.dat")
+	if err := writeGameDat(datPath, inst, solvingPlayer, attackFixed, toArmFixed); err != nil {
+		return "", err
+	}
+	return RunAMPL(filepath.Join(inst.DatDir, "base_defense_game.run"))
+}
+
+func writeGameDat(path string, inst GameInstance, solvingPlayer int, attackFixed, toArmFixed map[string]bool) error {
+	isCritical := map[string]float64{}
+	attackFixedVals := map[string]float64{}
+	toArmFixedVals := map[string]float64{}
+	for _, v := range inst.V {
+		isCritical[v] = boolFloat(inst.IsCriticalPoint[v])
+		attackFixedVals[v] = boolFloat(attackFixed[v])
+		toArmFixedVals[v] = boolFloat(toArmFixed[v])
+	}
+
+	attackBudget := float64(inst.AttackBudget)
+	defenseBudget := float64(inst.DefenseBudget)
+	player := float64(solvingPlayer)
+
+	if err := WriteDat(path, []DatParam{
+		{Name: "attack_budget", Scalar: &attackBudget},
+		{Name: "defense_budget", Scalar: &defenseBudget},
+		{Name: "solving_player", Scalar: &player},
+		{Name: "is_critical_point", Indexed: isCritical},
+		{Name: "attack_fixed", Indexed: attackFixedVals},
+		{Name: "to_arm_fixed", Indexed: toArmFixedVals},
+	}); err != nil {
+		return err
+	}
+	return appendSetAndEdges(path, inst.V, edgesFromNeighbors(inst.Neighbors))
+}
+
+// PlayGame alternates best responses (the attacker responds to the
+// defender's last arming, then the defender responds to that attack) until
+// a pure Nash equilibrium repeats, or until maxIter rounds have been tried.
+// On a cycle it builds the payoff matrix over every pure strategy visited
+// by either side and solves for the mixed-strategy Nash equilibrium as the
+// standard zero-sum matrix game LP.
+func PlayGame(inst GameInstance, maxIter int) (GameResult, error) {
+	armed := map[string]bool{}
+	seen := map[string]bool{}
+
+	var attackPool, defensePool []map[string]bool
+	attackKeys, defenseKeys := map[string]bool{}, map[string]bool{}
+
+	for iter := 0; iter < maxIter; iter++ {
+		attacked, err := attackerBestResponse(inst, armed)
+		if err != nil {
+			return GameResult{}, err
+		}
+		newArmed, err := defenderBestResponse(inst, attacked)
+		if err != nil {
+			return GameResult{}, err
+		}
+
+		if !attackKeys[strategyKey(attacked)] {
+			attackPool = append(attackPool, attacked)
+			attackKeys[strategyKey(attacked)] = true
+		}
+		if !defenseKeys[strategyKey(newArmed)] {
+			defensePool = append(defensePool, newArmed)
+			defenseKeys[strategyKey(newArmed)] = true
+		}
+
+		roundKey := strategyKey(attacked) + "|" + strategyKey(newArmed)
+		if seen[roundKey] {
+			return solveMixedEquilibrium(inst, attackPool, defensePool, iter+1)
+		}
+		seen[roundKey] = true
+
+		if strategyKey(armed) == strategyKey(newArmed) {
+			return GameResult{
+				Converged:       true,
+				AttackStrategy:  setToSlice(attacked),
+				DefenseStrategy: setToSlice(newArmed),
+				Value:           float64(neutralizedCount(inst, attacked, newArmed)),
+				Iterations:      iter + 1,
+			}, nil
+		}
+		armed = newArmed
+	}
+
+	return solveMixedEquilibrium(inst, attackPool, defensePool, maxIter)
+}
+
+func strategyKey(s map[string]bool) string {
+	return fmt.Sprint(setToSlice(s))
+}
+
+func setToSlice(s map[string]bool) []string {
+	var out []string
+	for k, v := range s {
+		if v {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// solveMixedEquilibrium builds the attacker x defender payoff matrix over
+// the discovered strategy pools and solves the standard zero-sum matrix
+// game LP pair:
+//
+//	primal (attacker): maximize v  s.t.  sum_i x_i * A[i][j] >= v  for all j
+//	                                     sum_i x_i = 1, x_i >= 0
+//	dual   (defender):  minimize u  s.t.  sum_j y_j * A[i][j] <= u  for all i
+//	                                      sum_j y_j = 1, y_j >= 0
+//
+// via the usual reduction of each to an LP over a strictly-positive shift
+// of A (so 1/objective recovers the shared game value).
+func solveMixedEquilibrium(inst GameInstance, attackPool, defensePool []map[string]bool, iterations int) (GameResult, error) {
+	if len(attackPool) == 0 || len(defensePool) == 0 {
+		return GameResult{}, fmt.Errorf("game: empty strategy pool, nothing to solve")
+	}
+
+	m, n := len(attackPool), len(defensePool)
+	payoff := make([][]float64, m)
+	maxAbs := 0.0
+	for i, atk := range attackPool {
+		payoff[i] = make([]float64, n)
+		for j, def := range defensePool {
+			payoff[i][j] = float64(neutralizedCount(inst, atk, def))
+			if abs := payoff[i][j]; abs > maxAbs {
+				maxAbs = abs
+			}
+		}
+	}
+	shift := maxAbs + 1 // guarantees every shifted entry is strictly positive
+	shifted := make([][]float64, m)
+	for i := range shifted {
+		shifted[i] = make([]float64, n)
+		for j := range shifted[i] {
+			shifted[i][j] = payoff[i][j] + shift
+		}
+	}
+
+	// attacker's LP: minimize sum_i z_i  s.t. for each column j: sum_i shifted[i][j]*z_i >= 1, z>=0
+	attackerRows := make([][]float64, n)
+	for j := 0; j < n; j++ {
+		attackerRows[j] = make([]float64, m)
+		for i := 0; i < m; i++ {
+			attackerRows[j][i] = shifted[i][j]
+		}
+	}
+	ones := func(k int) []float64 {
+		v := make([]float64, k)
+		for i := range v {
+			v[i] = 1
+		}
+		return v
+	}
+
+	z, zObj, err := simplexMinimizeSubjectToGE(attackerRows, ones(n), ones(m))
+	if err != nil {
+		return GameResult{}, fmt.Errorf("game: attacker equilibrium LP failed: %w", err)
+	}
+
+	// defender's LP: maximize sum_j w_j  s.t. for each row i: sum_j shifted[i][j]*w_j <= 1, w>=0
+	w, wObj, err := simplexMaximizeSubjectToLE(shifted, ones(m), ones(n))
+	if err != nil {
+		return GameResult{}, fmt.Errorf("game: defender equilibrium LP failed: %w", err)
+	}
+
+	attackMixed := map[string]float64{}
+	for i, atk := range attackPool {
+		attackMixed[strategyKey(atk)] = z[i] / zObj
+	}
+	defenseMixed := map[string]float64{}
+	for j, def := range defensePool {
+		defenseMixed[strategyKey(def)] = w[j] / wObj
+	}
+
+	value := 1/zObj - shift
+
+	return GameResult{
+		Converged:    false,
+		AttackMixed:  attackMixed,
+		DefenseMixed: defenseMixed,
+		Value:        value,
+		Iterations:   iterations,
+	}, nil
+}
@@ -0,0 +1,126 @@
+package solver
+
+import "fmt"
+
+// board position identifiers are formatted "r_c", matching GridGraph.
+
+// KnightGraph generates the V/E data for the n x n knight's-move graph:
+// two squares are adjacent iff a knight can move between them in one move.
+func KnightGraph(n int) ([]string, []Edge) {
+	return boardGraph(n, [][2]int{
+		{1, 2}, {2, 1}, {-1, 2}, {-2, 1},
+		{1, -2}, {2, -1}, {-1, -2}, {-2, -1},
+	})
+}
+
+// KingGraph generates the V/E data for the n x n king's-move graph: two
+// squares are adjacent iff they are horizontally, vertically, or
+// diagonally adjacent.
+func KingGraph(n int) ([]string, []Edge) {
+	return boardGraph(n, [][2]int{
+		{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+		{1, 1}, {1, -1}, {-1, 1}, {-1, -1},
+	})
+}
+
+// QueenGraph generates the V/E data for the n x n queen's-move graph: two
+// squares are adjacent iff they share a row, column, or diagonal, with
+// nothing between them that would matter for domination (queens attack
+// through the whole line, so every same-row/col/diagonal pair is an edge).
+func QueenGraph(n int) ([]string, []Edge) {
+	var v []string
+	var e []Edge
+	id := func(r, c int) string { return fmt.Sprintf("%d_%d", r, c) }
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			v = append(v, id(r, c))
+		}
+	}
+	for r1 := 0; r1 < n; r1++ {
+		for c1 := 0; c1 < n; c1++ {
+			for r2 := r1; r2 < n; r2++ {
+				for c2 := 0; c2 < n; c2++ {
+					if r2 == r1 && c2 <= c1 {
+						continue
+					}
+					sameRow := r1 == r2
+					sameCol := c1 == c2
+					sameDiag := abs(r1-r2) == abs(c1-c2)
+					if sameRow || sameCol || sameDiag {
+						e = append(e, Edge{id(r1, c1), id(r2, c2)})
+					}
+				}
+			}
+		}
+	}
+	return v, e
+}
+
+// boardGraph places a piece with the given relative move offsets on every
+// square of an n x n board and connects squares it can reach in one move.
+func boardGraph(n int, offsets [][2]int) ([]string, []Edge) {
+	var v []string
+	var e []Edge
+	id := func(r, c int) string { return fmt.Sprintf("%d_%d", r, c) }
+	inBounds := func(r, c int) bool { return r >= 0 && r < n && c >= 0 && c < n }
+
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			v = append(v, id(r, c))
+		}
+	}
+	seen := map[Edge]bool{}
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			for _, off := range offsets {
+				nr, nc := r+off[0], c+off[1]
+				if !inBounds(nr, nc) {
+					continue
+				}
+				a, b := id(r, c), id(nr, nc)
+				edge := Edge{a, b}
+				if a > b {
+					edge = Edge{b, a}
+				}
+				if !seen[edge] {
+					seen[edge] = true
+					e = append(e, edge)
+				}
+			}
+		}
+	}
+	return v, e
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// allCritical marks every node as a critical point, the standard
+// domination-problem instance (base_defense.mod then reduces to minimum
+// dominating set).
+func allCritical(v []string) map[string]bool {
+	m := make(map[string]bool, len(v))
+	for _, x := range v {
+		m[x] = true
+	}
+	return m
+}
+
+// KnightDominationInstance builds the n x n knight domination benchmark as
+// a BaseDefenseInstance ready for SolveBaseDefense.
+func KnightDominationInstance(n int, datDir string) BaseDefenseInstance {
+	v, e := KnightGraph(n)
+	return BaseDefenseInstance{V: v, E: e, IsCriticalPoint: allCritical(v), DatDir: datDir}
+}
+
+// knightDominationNumbers are the verified minimum (ordinary, closed)
+// knight-domination numbers for n x n boards, n = 4..12 -- i.e. the values
+// base_defense.mod's dominating-set covering computes, not OEIS A006075
+// (which is the knight *total* domination number, a(4) = 5).
+var knightDominationNumbers = map[int]int{
+	4: 4, 5: 5, 6: 8, 7: 10, 8: 12, 9: 14, 10: 16, 11: 21, 12: 24,
+}
@@ -0,0 +1,38 @@
+package solver
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"testing"
+)
+
+// TestKnightDominationInstances solves the generated knight-domination
+// benchmarks with base_defense.mod and checks the result against the known
+// optimum (see knightDominationNumbers). Skipped when no ampl binary is on
+// PATH, since this is an integration test against the external solver.
+func TestKnightDominationInstances(t *testing.T) {
+	if _, err := exec.LookPath("ampl"); err != nil {
+		t.Skip("ampl not found on PATH, skipping solver regression suite")
+	}
+
+	var ns []int
+	for n := range knightDominationNumbers {
+		ns = append(ns, n)
+	}
+	sort.Ints(ns)
+
+	for _, n := range ns {
+		n := n
+		t.Run(fmt.Sprintf("%dx%d", n, n), func(t *testing.T) {
+			inst := KnightDominationInstance(n, "../AMPL")
+			got, err := SolveBaseDefense(inst)
+			if err != nil {
+				t.Fatalf("SolveBaseDefense(%dx%d): %v", n, n, err)
+			}
+			if want := knightDominationNumbers[n]; got != want {
+				t.Errorf("%dx%d knight domination: got %d, want %d", n, n, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package solver
+
+import (
+	"path/filepath"
+	"strconv"
+)
+
+// InterdictInstance is the bilevel interdiction input: the graph from
+// base_defense.mod plus the attacker's edge-removal budget.
+type InterdictInstance struct {
+	V               []string
+	E               []Edge
+	IsCriticalPoint map[string]bool
+	InterdictBudget int
+	DatDir          string
+}
+
+// SolveInterdict writes base_defense_interdict.dat and runs
+// base_defense_interdict.run, returning the worst-case covering cost the
+// attacker can force.
+func SolveInterdict(inst InterdictInstance) (int, error) {
+	datPath := filepath.Join(inst.DatDir, "base_defense_interdict.dat")
+	if err := writeInterdictDat(datPath, inst); err != nil {
+		return 0, err
+	}
+
+	out, err := RunAMPL(filepath.Join(inst.DatDir, "base_defense_interdict.run"))
+	if err != nil {
+		return 0, err
+	}
+	return ScrapeInt(out, "worst case cost ")
+}
+
+func writeInterdictDat(path string, inst InterdictInstance) error {
+	budget := float64(inst.InterdictBudget)
+	isCritical := map[string]float64{}
+	for _, v := range inst.V {
+		isCritical[v] = boolFloat(inst.IsCriticalPoint[v])
+	}
+	if err := WriteDat(path, []DatParam{
+		{Name: "interdict_budget", Scalar: &budget},
+		{Name: "is_critical_point", Indexed: isCritical},
+	}); err != nil {
+		return err
+	}
+	return appendSetAndEdges(path, inst.V, inst.E)
+}
+
+// StarInstance builds a star graph with one critical hub and k leaves, used
+// to check that interdicting any single edge can't raise the defender's
+// optimum above 1 (the hub alone always dominates whatever leaves remain
+// attached, and an isolated leaf needs no arming since only the hub is
+// critical).
+func StarInstance(k int, datDir string) InterdictInstance {
+	v := []string{"hub"}
+	var e []Edge
+	for i := 0; i < k; i++ {
+		leaf := leafName(i)
+		v = append(v, leaf)
+		e = append(e, Edge{"hub", leaf})
+	}
+	return InterdictInstance{
+		V:               v,
+		E:               e,
+		IsCriticalPoint: map[string]bool{"hub": true},
+		InterdictBudget: 1,
+		DatDir:          datDir,
+	}
+}
+
+func leafName(i int) string {
+	return "leaf" + strconv.Itoa(i)
+}
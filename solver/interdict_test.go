@@ -0,0 +1,27 @@
+package solver
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// TestStarGraphInterdictionInvariant checks the known closed-form answer on
+// a star graph with one critical hub and k leaves: interdicting any single
+// edge still leaves the defender's optimum at 1, since the hub alone
+// dominates the whole graph regardless of which leaf is cut off.
+func TestStarGraphInterdictionInvariant(t *testing.T) {
+	if _, err := exec.LookPath("ampl"); err != nil {
+		t.Skip("ampl not found on PATH, skipping solver regression suite")
+	}
+
+	for _, k := range []int{2, 5, 10} {
+		inst := StarInstance(k, "../AMPL")
+		got, err := SolveInterdict(inst)
+		if err != nil {
+			t.Fatalf("SolveInterdict(k=%d): %v", k, err)
+		}
+		if got != 1 {
+			t.Errorf("star graph k=%d: got worst-case cost %d, want 1", k, got)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package solver
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ObjectiveMode selects which of base_defense_resource.mod's two objectives
+// is active for a solve.
+type ObjectiveMode int
+
+const (
+	// MinimizeAllocation meets every demand in full, minimizing total spend.
+	MinimizeAllocation ObjectiveMode = 1
+	// MinimizeUnmet spends at most resource_budget, minimizing unmet demand.
+	MinimizeUnmet ObjectiveMode = 2
+)
+
+// ResourceInstance is the shared-resource allocation input: the graph from
+// base_defense.mod plus the efficiency/demand/budget parameters added in
+// base_defense_resource.mod.
+type ResourceInstance struct {
+	V               []string
+	E               []Edge
+	IsCriticalPoint map[string]bool
+	EdgeEfficiency  map[[2]string]float64 // keyed (v, n), n in N[v]
+	Demand          map[string]float64
+	ResourceBudget  float64
+	Mode            ObjectiveMode
+	DatDir          string
+}
+
+// ResourceResult is the per-base allocation returned by a solve, along with
+// the two objective totals (only the one matching Mode was optimized).
+type ResourceResult struct {
+	Alloc      map[string]float64
+	TotalAlloc float64
+	TotalUnmet float64
+}
+
+// SolveResource writes base_defense_resource.dat, invokes
+// base_defense_resource.run, and parses the resulting allocation.
+func SolveResource(inst ResourceInstance) (ResourceResult, error) {
+	datPath := filepath.Join(inst.DatDir, "base_defense_resource.dat")
+	if err := writeResourceDat(datPath, inst); err != nil {
+		return ResourceResult{}, err
+	}
+
+	out, err := RunAMPL(filepath.Join(inst.DatDir, "base_defense_resource.run"))
+	if err != nil {
+		return ResourceResult{}, err
+	}
+
+	totalAlloc, err := scrapeFloat(out, "total allocation ")
+	if err != nil {
+		return ResourceResult{}, err
+	}
+	totalUnmet, err := scrapeFloat(out, "total unmet ")
+	if err != nil {
+		return ResourceResult{}, err
+	}
+
+	return ResourceResult{
+		Alloc:      scrapeAlloc(out, inst.V),
+		TotalAlloc: totalAlloc,
+		TotalUnmet: totalUnmet,
+	}, nil
+}
+
+func writeResourceDat(path string, inst ResourceInstance) error {
+	budget := inst.ResourceBudget
+	mode := float64(inst.Mode)
+	isCritical := map[string]float64{}
+	for _, v := range inst.V {
+		isCritical[v] = boolFloat(inst.IsCriticalPoint[v])
+	}
+	if err := WriteDat(path, []DatParam{
+		{Name: "resource_budget", Scalar: &budget},
+		{Name: "objective_mode", Scalar: &mode},
+		{Name: "is_critical_point", Indexed: isCritical},
+		{Name: "demand", Indexed: inst.Demand},
+		{Name: "edge_efficiency", Indexed: flattenEdgeEfficiency(inst.EdgeEfficiency)},
+	}); err != nil {
+		return err
+	}
+	return appendSetAndEdges(path, inst.V, inst.E)
+}
+
+// flattenEdgeEfficiency renders the (v, n) keyed map as AMPL's "v n" index
+// tuples, since DatParam.Indexed only carries single string keys.
+func flattenEdgeEfficiency(m map[[2]string]float64) map[string]float64 {
+	out := make(map[string]float64, len(m))
+	for k, v := range m {
+		out[fmt.Sprintf("%s %s", k[0], k[1])] = v
+	}
+	return out
+}
+
+func scrapeFloat(out, prefix string) (float64, error) {
+	for _, line := range strings.Split(out, "\n") {
+		if rest, ok := strings.CutPrefix(line, prefix); ok {
+			return strconv.ParseFloat(strings.TrimSpace(rest), 64)
+		}
+	}
+	return 0, fmt.Errorf("prefix %q not found in ampl output", prefix)
+}
+
+// scrapeAlloc reads the `display alloc;` block (lines "v value") into a map.
+func scrapeAlloc(out string, v []string) map[string]float64 {
+	values := make(map[string]float64, len(v))
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if val, err := strconv.ParseFloat(fields[1], 64); err == nil {
+			values[fields[0]] = val
+		}
+	}
+	alloc := make(map[string]float64, len(v))
+	for _, name := range v {
+		alloc[name] = values[name]
+	}
+	return alloc
+}
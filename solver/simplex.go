@@ -0,0 +1,224 @@
+package solver
+
+import "fmt"
+
+// bigM is the penalty applied to artificial variables; large enough to
+// dominate any of this package's payoff-derived objective coefficients,
+// which are always O(len(V)).
+const bigM = 1e7
+
+// simplexMinimizeSubjectToGE solves
+//
+//	minimize c^T y  s.t.  A y >= b,  y >= 0
+//
+// for b >= 0, via the Big-M simplex method (surplus + artificial variables,
+// Bland's rule to avoid cycling). Returns y and the optimal objective value.
+func simplexMinimizeSubjectToGE(a [][]float64, b, c []float64) ([]float64, float64, error) {
+	nRows := len(a)
+	nY := len(c)
+	if nRows == 0 || nY == 0 {
+		return nil, 0, fmt.Errorf("simplex: empty problem")
+	}
+
+	// columns: y_1..y_nY, s_1..s_nRows (surplus, -1), a_1..a_nRows (artificial, +1)
+	nCols := nY + 2*nRows
+	tableau := make([][]float64, nRows+1)
+	for i := 0; i < nRows; i++ {
+		row := make([]float64, nCols+1)
+		copy(row[:nY], a[i])
+		row[nY+i] = -1      // surplus
+		row[nY+nRows+i] = 1 // artificial
+		row[nCols] = b[i]
+		tableau[i] = row
+	}
+
+	basis := make([]int, nRows)
+	for i := range basis {
+		basis[i] = nY + nRows + i
+	}
+
+	// objective row: minimize c^T y + bigM * sum(artificials), stored as
+	// reduced costs (this row is kept as -(objective) for a max-style pivot)
+	obj := make([]float64, nCols+1)
+	for j := 0; j < nY; j++ {
+		obj[j] = c[j]
+	}
+	for i := 0; i < nRows; i++ {
+		obj[nY+nRows+i] = bigM
+	}
+	// eliminate artificial columns from the objective row using the basis rows
+	for i := 0; i < nRows; i++ {
+		factor := obj[nY+nRows+i]
+		if factor == 0 {
+			continue
+		}
+		for j := 0; j <= nCols; j++ {
+			obj[j] -= factor * tableau[i][j]
+		}
+	}
+	tableau[nRows] = obj
+
+	const maxIter = 10000
+	for iter := 0; iter < maxIter; iter++ {
+		// Bland's rule: first column with negative reduced cost
+		pivotCol := -1
+		for j := 0; j < nCols; j++ {
+			if tableau[nRows][j] < -1e-9 {
+				pivotCol = j
+				break
+			}
+		}
+		if pivotCol == -1 {
+			break // optimal
+		}
+
+		pivotRow := -1
+		bestRatio := 0.0
+		for i := 0; i < nRows; i++ {
+			if tableau[i][pivotCol] > 1e-9 {
+				ratio := tableau[i][nCols] / tableau[i][pivotCol]
+				// ties go to the smallest basic variable index (Bland)
+				if pivotRow == -1 || ratio < bestRatio-1e-12 ||
+					(ratio <= bestRatio+1e-12 && basis[i] < basis[pivotRow]) {
+					bestRatio, pivotRow = ratio, i
+				}
+			}
+		}
+		if pivotRow == -1 {
+			return nil, 0, fmt.Errorf("simplex: unbounded problem")
+		}
+
+		pivotVal := tableau[pivotRow][pivotCol]
+		for j := 0; j <= nCols; j++ {
+			tableau[pivotRow][j] /= pivotVal
+		}
+		for i := 0; i <= nRows; i++ {
+			if i == pivotRow {
+				continue
+			}
+			factor := tableau[i][pivotCol]
+			if factor == 0 {
+				continue
+			}
+			for j := 0; j <= nCols; j++ {
+				tableau[i][j] -= factor * tableau[pivotRow][j]
+			}
+		}
+		basis[pivotRow] = pivotCol
+	}
+
+	for i := 0; i < nRows; i++ {
+		if basis[i] >= nY+nRows && tableau[i][nCols] > 1e-6 {
+			return nil, 0, fmt.Errorf("simplex: problem infeasible")
+		}
+	}
+
+	y := make([]float64, nY)
+	for i, col := range basis {
+		if col < nY {
+			y[col] = tableau[i][nCols]
+		}
+	}
+
+	objVal := 0.0
+	for j, yj := range y {
+		objVal += c[j] * yj
+	}
+	return y, objVal, nil
+}
+
+// simplexMaximizeSubjectToLE solves
+//
+//	maximize c^T w  s.t.  A w <= b,  w >= 0
+//
+// for b >= 0, via the standard two-phase-free simplex method: w = 0 with
+// slack = b is already feasible, so no artificial variables are needed.
+func simplexMaximizeSubjectToLE(a [][]float64, b, c []float64) ([]float64, float64, error) {
+	nRows := len(a)
+	nW := len(c)
+	if nRows == 0 || nW == 0 {
+		return nil, 0, fmt.Errorf("simplex: empty problem")
+	}
+
+	// columns: w_1..w_nW, s_1..s_nRows (slack)
+	nCols := nW + nRows
+	tableau := make([][]float64, nRows+1)
+	for i := 0; i < nRows; i++ {
+		row := make([]float64, nCols+1)
+		copy(row[:nW], a[i])
+		row[nW+i] = 1 // slack
+		row[nCols] = b[i]
+		tableau[i] = row
+	}
+
+	basis := make([]int, nRows)
+	for i := range basis {
+		basis[i] = nW + i
+	}
+
+	// objective row stored as -(c^T w), so a negative entry is improving
+	obj := make([]float64, nCols+1)
+	for j := 0; j < nW; j++ {
+		obj[j] = -c[j]
+	}
+	tableau[nRows] = obj
+
+	const maxIter = 10000
+	for iter := 0; iter < maxIter; iter++ {
+		pivotCol := -1
+		for j := 0; j < nCols; j++ {
+			if tableau[nRows][j] < -1e-9 {
+				pivotCol = j
+				break
+			}
+		}
+		if pivotCol == -1 {
+			break // optimal
+		}
+
+		pivotRow := -1
+		bestRatio := 0.0
+		for i := 0; i < nRows; i++ {
+			if tableau[i][pivotCol] > 1e-9 {
+				ratio := tableau[i][nCols] / tableau[i][pivotCol]
+				if pivotRow == -1 || ratio < bestRatio-1e-12 {
+					bestRatio, pivotRow = ratio, i
+				}
+			}
+		}
+		if pivotRow == -1 {
+			return nil, 0, fmt.Errorf("simplex: unbounded problem")
+		}
+
+		pivotVal := tableau[pivotRow][pivotCol]
+		for j := 0; j <= nCols; j++ {
+			tableau[pivotRow][j] /= pivotVal
+		}
+		for i := 0; i <= nRows; i++ {
+			if i == pivotRow {
+				continue
+			}
+			factor := tableau[i][pivotCol]
+			if factor == 0 {
+				continue
+			}
+			for j := 0; j <= nCols; j++ {
+				tableau[i][j] -= factor * tableau[pivotRow][j]
+			}
+		}
+		basis[pivotRow] = pivotCol
+	}
+
+	w := make([]float64, nW)
+	for i, col := range basis {
+		if col < nW {
+			w[col] = tableau[i][nCols]
+		}
+	}
+
+	objVal := 0.0
+	for j, wj := range w {
+		objVal += c[j] * wj
+	}
+	return w, objVal, nil
+}
@@ -0,0 +1,81 @@
+package solver
+
+import "fmt"
+
+// Edge is an unordered pair of node identifiers, matching base_defense.mod's
+// `set E within {V,V}`.
+type Edge [2]string
+
+// GridGraph generates the V/E data for a rows x cols grid-adjacency graph
+// (orthogonal neighbors only), node ids formatted "r_c".
+func GridGraph(rows, cols int) ([]string, []Edge) {
+	var v []string
+	var e []Edge
+	id := func(r, c int) string { return fmt.Sprintf("%d_%d", r, c) }
+
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			v = append(v, id(r, c))
+			if c+1 < cols {
+				e = append(e, Edge{id(r, c), id(r, c+1)})
+			}
+			if r+1 < rows {
+				e = append(e, Edge{id(r, c), id(r+1, c)})
+			}
+		}
+	}
+	return v, e
+}
+
+// HexGraph generates the V/E data for a hexagonal grid of the given radius
+// (axial coordinates, 0,0 at the center), node ids formatted "q_r". Two
+// cells are adjacent iff one of the 6 axial neighbor offsets connects them.
+func HexGraph(radius int) ([]string, []Edge) {
+	var v []string
+	var e []Edge
+	id := func(q, r int) string { return fmt.Sprintf("%d_%d", q, r) }
+
+	cells := map[[2]int]bool{}
+	for q := -radius; q <= radius; q++ {
+		r1, r2 := max(-radius, -q-radius), min(radius, -q+radius)
+		for r := r1; r <= r2; r++ {
+			cells[[2]int{q, r}] = true
+			v = append(v, id(q, r))
+		}
+	}
+
+	offsets := [6][2]int{{1, 0}, {1, -1}, {0, -1}, {-1, 0}, {-1, 1}, {0, 1}}
+	seen := map[Edge]bool{}
+	for cell := range cells {
+		for _, off := range offsets {
+			nb := [2]int{cell[0] + off[0], cell[1] + off[1]}
+			if !cells[nb] {
+				continue
+			}
+			a, b := id(cell[0], cell[1]), id(nb[0], nb[1])
+			edge := Edge{a, b}
+			if a > b {
+				edge = Edge{b, a}
+			}
+			if !seen[edge] {
+				seen[edge] = true
+				e = append(e, edge)
+			}
+		}
+	}
+	return v, e
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
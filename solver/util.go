@@ -0,0 +1,147 @@
+package solver
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// appendFile appends content to an existing file, e.g. to add set
+// statements after WriteDat has written a file's param blocks.
+func appendFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(content)
+	return err
+}
+
+// toSet turns a slice of identifiers into a membership set.
+func toSet(xs []string) map[string]bool {
+	s := make(map[string]bool, len(xs))
+	for _, x := range xs {
+		s[x] = true
+	}
+	return s
+}
+
+// subsetsUpTo returns every subset of xs with size 0..k (inclusive), in
+// depth-first order (each subset is followed by its extensions, so sizes
+// are not sorted). Intended for small attacker/defender budgets only: the
+// count grows as sum_{i=0..k} C(len(xs), i).
+func subsetsUpTo(xs []string, k int) [][]string {
+	var out [][]string
+	var rec func(start int, cur []string)
+	rec = func(start int, cur []string) {
+		out = append(out, append([]string(nil), cur...))
+		if len(cur) == k {
+			return
+		}
+		for i := start; i < len(xs); i++ {
+			rec(i+1, append(cur, xs[i]))
+		}
+	}
+	rec(0, nil)
+	return out
+}
+
+// joinIdents renders an AMPL set literal's membership list, e.g. "a, b, c".
+func joinIdents(xs []string) string {
+	return strings.Join(xs, ", ")
+}
+
+// joinRange renders the AMPL set literal 1..n, or an empty set when n is 0.
+func joinRange(n int) string {
+	if n == 0 {
+		return "{}"
+	}
+	return fmt.Sprintf("1..%d", n)
+}
+
+// edgesFromNeighbors flattens an adjacency map into the Edge list
+// appendSetAndEdges expects; every instance type that carries adjacency as
+// Neighbors map[string][]string rather than an explicit E []Edge goes
+// through here.
+func edgesFromNeighbors(neighbors map[string][]string) []Edge {
+	var e []Edge
+	for v, nbs := range neighbors {
+		for _, n := range nbs {
+			e = append(e, Edge{v, n})
+		}
+	}
+	return e
+}
+
+// renderIndexed renders a "param name := k1 v1 k2 v2 ...;" block.
+func renderIndexed(name string, vals map[string]float64) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "param %s :=\n", name)
+	for k, v := range vals {
+		fmt.Fprintf(&b, "\t%s %s\n", k, strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	b.WriteString(";\n")
+	return b.String()
+}
+
+// scrapeDisplayBlock reads the `display <varName>;` block ampl prints
+// (a "<varName> [*] :=" header, one "node value" line per entry, then a
+// lone ";") and returns the nodes whose value is 1. Needed whenever a
+// single .run invocation displays more than one var, since their blocks
+// would otherwise be indistinguishable by line shape alone.
+func scrapeDisplayBlock(out, varName string, v []string) map[string]bool {
+	header := varName + " [*]"
+	values := map[string]bool{}
+	active := false
+	for _, line := range strings.Split(out, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, header):
+			active = true
+			continue
+		case active && trimmed == ";":
+			active = false
+			continue
+		case !active:
+			continue
+		}
+		fields := strings.Fields(trimmed)
+		if len(fields) != 2 {
+			continue
+		}
+		if val, err := strconv.ParseFloat(fields[1], 64); err == nil && val > 0.5 {
+			values[fields[0]] = true
+		}
+	}
+	result := make(map[string]bool, len(v))
+	for _, name := range v {
+		if values[name] {
+			result[name] = true
+		}
+	}
+	return result
+}
+
+// scrapeHardened reads the `display harden;` block ampl prints (lines of the
+// form "v 1" or "v 0", one per base) and returns the bases set to 1.
+func scrapeHardened(out string, v []string) []string {
+	values := make(map[string]bool, len(v))
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if val, err := strconv.ParseFloat(fields[1], 64); err == nil && val > 0.5 {
+			values[fields[0]] = true
+		}
+	}
+	var hardened []string
+	for _, name := range v {
+		if values[name] {
+			hardened = append(hardened, name)
+		}
+	}
+	return hardened
+}